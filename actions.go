@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"net-tui/collector"
+)
+
+// inputMode tracks whether the user is in a modal interaction on top of the
+// normal tab/navigate flow.
+type inputMode int
+
+const (
+	modeNormal inputMode = iota
+	modeConfirm
+	modeFilter
+)
+
+// confirmState describes a pending destructive action awaiting y/n.
+type confirmState struct {
+	pid   int32
+	label string
+	sig   syscall.Signal
+}
+
+// handleActionKey processes the row-action and filter key bindings that sit
+// on top of the normal navigation handled in handleKey. It returns handled
+// = false when the key isn't one of these bindings, so the caller falls
+// through to its own switch.
+func (m model) handleActionKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch m.mode {
+	case modeConfirm:
+		return m.handleConfirmKey(msg), nil, true
+	case modeFilter:
+		return m.handleFilterKey(msg)
+	}
+
+	switch msg.String() {
+	case "x":
+		return m.startConfirm(syscall.SIGTERM, "SIGTERM"), nil, true
+	case "X":
+		return m.startConfirm(syscall.SIGKILL, "SIGKILL"), nil, true
+	case "y":
+		m.copySelected()
+		return m, nil, true
+	case "/":
+		m.mode = modeFilter
+		ti := textinput.New()
+		ti.Placeholder = "filter by process, address, state..."
+		ti.SetValue(m.filterQuery)
+		ti.CursorEnd()
+		ti.Focus()
+		m.filterInput = ti
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+func (m model) startConfirm(sig syscall.Signal, label string) model {
+	pid, ok := m.selectedPID()
+	if !ok {
+		return m
+	}
+	m.mode = modeConfirm
+	m.confirm = &confirmState{pid: pid, label: label, sig: sig}
+	return m
+}
+
+func (m model) handleConfirmKey(msg tea.KeyMsg) model {
+	if m.confirm == nil {
+		m.mode = modeNormal
+		return m
+	}
+	switch msg.String() {
+	case "y", "enter":
+		pid, sig := m.confirm.pid, m.confirm.sig
+		if err := syscall.Kill(int(pid), sig); err != nil {
+			m.actionMsg = fmt.Sprintf("signal %d failed: %v", pid, err)
+		} else {
+			m.actionMsg = fmt.Sprintf("sent %s to %d", m.confirm.label, pid)
+		}
+		m.mode = modeNormal
+		m.confirm = nil
+	case "n", "esc":
+		m.mode = modeNormal
+		m.confirm = nil
+	}
+	return m
+}
+
+func (m model) handleFilterKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		m.filterQuery = m.filterInput.Value()
+		m.mode = modeNormal
+		m.cursor, m.offset = 0, 0
+		return m, nil, true
+	case "esc":
+		m.mode = modeNormal
+		return m, nil, true
+	}
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	return m, cmd, true
+}
+
+// selectedPID returns the PID of the row under the cursor on tabs that have
+// one, so the kill bindings know what to signal.
+func (m model) selectedPID() (int32, bool) {
+	switch m.tab {
+	case tabConnections:
+		list := m.filteredConnections()
+		if m.cursor >= 0 && m.cursor < len(list) && list[m.cursor].PID > 0 {
+			return list[m.cursor].PID, true
+		}
+	case tabPorts:
+		list := m.filteredPorts()
+		if m.cursor >= 0 && m.cursor < len(list) && list[m.cursor].PID > 0 {
+			return list[m.cursor].PID, true
+		}
+	}
+	return 0, false
+}
+
+// copySelected writes the selected row's address to the system clipboard.
+func (m *model) copySelected() {
+	text, ok := m.selectedCopyText()
+	if !ok {
+		return
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		m.actionMsg = fmt.Sprintf("copy failed: %v", err)
+		return
+	}
+	m.actionMsg = fmt.Sprintf("copied %s", text)
+}
+
+func (m model) selectedCopyText() (string, bool) {
+	switch m.tab {
+	case tabConnections:
+		list := m.filteredConnections()
+		if m.cursor < 0 || m.cursor >= len(list) {
+			return "", false
+		}
+		c := list[m.cursor]
+		if c.Remote != "" && c.Remote != "*:0" {
+			return c.Remote, true
+		}
+		return c.Local, true
+	case tabPorts:
+		list := m.filteredPorts()
+		if m.cursor < 0 || m.cursor >= len(list) {
+			return "", false
+		}
+		p := list[m.cursor]
+		addr := p.Addr
+		if addr == "" {
+			addr = "*"
+		}
+		return fmt.Sprintf("%s:%d", addr, p.Port), true
+	}
+	return "", false
+}
+
+// ----------------------------------------------------------------------------
+// Filtering
+// ----------------------------------------------------------------------------
+
+func matchesFilter(query string, haystacks ...string) bool {
+	if query == "" {
+		return true
+	}
+	query = strings.ToLower(query)
+	for _, h := range haystacks {
+		if strings.Contains(strings.ToLower(h), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// filteredConnections narrows m.connections by the active filter query,
+// matching on process name, local/remote address, or state.
+func (m model) filteredConnections() []collector.Connection {
+	if m.filterQuery == "" {
+		return m.connections
+	}
+	var out []collector.Connection
+	for _, c := range m.connections {
+		if matchesFilter(m.filterQuery, c.Process, c.Local, c.Remote, c.State) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// filteredPorts narrows m.ports by the active filter query, matching on
+// process name, address, or protocol.
+func (m model) filteredPorts() []collector.Port {
+	if m.filterQuery == "" {
+		return m.ports
+	}
+	var out []collector.Port
+	for _, p := range m.ports {
+		if matchesFilter(m.filterQuery, p.Process, p.Addr, p.Proto) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestMatchesFilter(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		haystacks []string
+		want      bool
+	}{
+		{name: "empty query matches everything", query: "", haystacks: []string{"nginx"}, want: true},
+		{name: "substring match", query: "ngi", haystacks: []string{"nginx", "127.0.0.1"}, want: true},
+		{name: "case insensitive", query: "NGINX", haystacks: []string{"nginx"}, want: true},
+		{name: "no match", query: "apache", haystacks: []string{"nginx", "127.0.0.1", "LISTEN"}, want: false},
+		{name: "matches a later haystack", query: "listen", haystacks: []string{"nginx", "LISTEN"}, want: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := matchesFilter(c.query, c.haystacks...); got != c.want {
+				t.Errorf("matchesFilter(%q, %v) = %v, want %v", c.query, c.haystacks, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"net-tui/collector"
+)
+
+// bandwidthWindow is how many rate samples we keep per interface: 60 ticks
+// at the 2-second refresh cadence, i.e. the last two minutes.
+const bandwidthWindow = 60
+
+// sparkBlocks are the Unicode block characters used to render a sparkline,
+// lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// rateSample is one instantaneous rx/tx rate measurement.
+type rateSample struct {
+	rx float64 // bytes/sec
+	tx float64 // bytes/sec
+}
+
+// ifaceTracker accumulates a rolling window of rx/tx throughput for one
+// interface, derived from the cumulative byte counters in each tick's
+// collector.Iface snapshot.
+type ifaceTracker struct {
+	lastRX, lastTX uint64
+	lastTime       time.Time
+	started        bool
+
+	startRX, startTX uint64
+	rates            []rateSample
+}
+
+// observe folds in a new cumulative counter reading, turning it into an
+// instantaneous rate sample once a prior reading exists.
+func (t *ifaceTracker) observe(ifc collector.Iface, now time.Time) {
+	if !t.started {
+		t.lastRX, t.lastTX = ifc.RX, ifc.TX
+		t.startRX, t.startTX = ifc.RX, ifc.TX
+		t.lastTime = now
+		t.started = true
+		return
+	}
+
+	dt := now.Sub(t.lastTime).Seconds()
+	if dt <= 0 {
+		dt = 1
+	}
+
+	t.rates = append(t.rates, rateSample{
+		rx: rateOf(ifc.RX, t.lastRX, dt),
+		tx: rateOf(ifc.TX, t.lastTX, dt),
+	})
+	if len(t.rates) > bandwidthWindow {
+		t.rates = t.rates[len(t.rates)-bandwidthWindow:]
+	}
+
+	t.lastRX, t.lastTX, t.lastTime = ifc.RX, ifc.TX, now
+}
+
+// rateOf computes bytes/sec between two cumulative readings, clamping to
+// zero if the counter went backwards (interface reset or re-enumeration).
+func rateOf(cur, prev uint64, dt float64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / dt
+}
+
+func (t *ifaceTracker) rxRate() float64 { return t.lastRate(func(s rateSample) float64 { return s.rx }) }
+func (t *ifaceTracker) txRate() float64 { return t.lastRate(func(s rateSample) float64 { return s.tx }) }
+
+func (t *ifaceTracker) lastRate(pick func(rateSample) float64) float64 {
+	if len(t.rates) == 0 {
+		return 0
+	}
+	return pick(t.rates[len(t.rates)-1])
+}
+
+// bandwidthStats summarizes the rolling window: min/avg/peak rate and total bytes
+// transferred since the tracker started observing this interface.
+type bandwidthStats struct {
+	min, avg, peak float64
+	total          uint64
+}
+
+func (t *ifaceTracker) rxStats() bandwidthStats {
+	return summarize(t.rates, func(s rateSample) float64 { return s.rx }, totalSince(t.lastRX, t.startRX))
+}
+
+func (t *ifaceTracker) txStats() bandwidthStats {
+	return summarize(t.rates, func(s rateSample) float64 { return s.tx }, totalSince(t.lastTX, t.startTX))
+}
+
+// totalSince computes bytes transferred since the tracker started,
+// clamping to zero if the counter went backwards (same case rateOf
+// guards against).
+func totalSince(last, start uint64) uint64 {
+	if last < start {
+		return 0
+	}
+	return last - start
+}
+
+func summarize(rates []rateSample, pick func(rateSample) float64, total uint64) bandwidthStats {
+	if len(rates) == 0 {
+		return bandwidthStats{total: total}
+	}
+	min, peak, sum := pick(rates[0]), pick(rates[0]), 0.0
+	for _, r := range rates {
+		v := pick(r)
+		if v < min {
+			min = v
+		}
+		if v > peak {
+			peak = v
+		}
+		sum += v
+	}
+	return bandwidthStats{min: min, avg: sum / float64(len(rates)), peak: peak, total: total}
+}
+
+// sparkline renders values as a single line of Unicode block characters
+// scaled against the largest value in the series.
+func sparkline(rates []rateSample, pick func(rateSample) float64, width int) string {
+	if len(rates) == 0 {
+		return strings.Repeat(string(sparkBlocks[0]), width)
+	}
+	if width > len(rates) {
+		width = len(rates)
+	}
+	series := rates[len(rates)-width:]
+
+	max := 0.0
+	for _, r := range series {
+		if v := pick(r); v > max {
+			max = v
+		}
+	}
+
+	var b strings.Builder
+	for _, r := range series {
+		v := pick(r)
+		idx := 0
+		if max > 0 {
+			idx = int(v / max * float64(len(sparkBlocks)-1))
+		}
+		if idx >= len(sparkBlocks) {
+			idx = len(sparkBlocks) - 1
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+// formatRate renders a bytes/sec figure the same way formatBytes renders a
+// byte count, with a "/s" suffix.
+func formatRate(bytesPerSec float64) string {
+	return formatBytes(uint64(bytesPerSec)) + "/s"
+}
+
+// observeBandwidth folds the current interface snapshot into each
+// interface's rolling rate window.
+func (m *model) observeBandwidth(now time.Time) {
+	for _, ifc := range m.interfaces {
+		t, ok := m.bandwidth[ifc.Name]
+		if !ok {
+			t = &ifaceTracker{}
+			m.bandwidth[ifc.Name] = t
+		}
+		t.observe(ifc, now)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Detail pane
+// ----------------------------------------------------------------------------
+
+// viewIfaceDetail renders the expanded chart and summary stats for the
+// selected interface.
+func viewIfaceDetail(ifc collector.Iface, t *ifaceTracker) string {
+	if t == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.dim.Render(strings.Repeat("─", 40)) + "\n")
+	b.WriteString(styles.header.Render(fmt.Sprintf("%s throughput", ifc.Name)) + "\n")
+
+	rxStats, txStats := t.rxStats(), t.txStats()
+	b.WriteString(fmt.Sprintf("RX %s\n", sparkline(t.rates, func(s rateSample) float64 { return s.rx }, 40)))
+	b.WriteString(styles.dim.Render(fmt.Sprintf(
+		"    min %s  avg %s  peak %s  total %s\n",
+		formatRate(rxStats.min), formatRate(rxStats.avg), formatRate(rxStats.peak), formatBytes(rxStats.total),
+	)))
+	b.WriteString(fmt.Sprintf("TX %s\n", sparkline(t.rates, func(s rateSample) float64 { return s.tx }, 40)))
+	b.WriteString(styles.dim.Render(fmt.Sprintf(
+		"    min %s  avg %s  peak %s  total %s\n",
+		formatRate(txStats.min), formatRate(txStats.avg), formatRate(txStats.peak), formatBytes(txStats.total),
+	)))
+
+	return b.String()
+}
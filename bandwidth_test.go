@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func TestRateOf(t *testing.T) {
+	cases := []struct {
+		name      string
+		cur, prev uint64
+		dt        float64
+		want      float64
+	}{
+		{name: "steady increase", cur: 2000, prev: 1000, dt: 2, want: 500},
+		{name: "no elapsed bytes", cur: 1000, prev: 1000, dt: 2, want: 0},
+		{name: "counter reset clamps to zero", cur: 10, prev: 1000, dt: 2, want: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := rateOf(c.cur, c.prev, c.dt); got != c.want {
+				t.Errorf("rateOf(%d, %d, %v) = %v, want %v", c.cur, c.prev, c.dt, got, c.want)
+			}
+		})
+	}
+}
+
+func TestTotalSince(t *testing.T) {
+	cases := []struct {
+		name        string
+		last, start uint64
+		want        uint64
+	}{
+		{name: "normal growth", last: 5000, start: 1000, want: 4000},
+		{name: "no growth", last: 1000, start: 1000, want: 0},
+		{name: "counter reset clamps to zero", last: 10, start: 1000, want: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := totalSince(c.last, c.start); got != c.want {
+				t.Errorf("totalSince(%d, %d) = %d, want %d", c.last, c.start, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	rates := []rateSample{{rx: 0}, {rx: 50}, {rx: 100}}
+	pick := func(s rateSample) float64 { return s.rx }
+
+	got := sparkline(rates, pick, 3)
+	want := string([]rune{sparkBlocks[0], sparkBlocks[3], sparkBlocks[len(sparkBlocks)-1]})
+	if got != want {
+		t.Errorf("sparkline(%v) = %q, want %q", rates, got, want)
+	}
+
+	if got := sparkline(nil, pick, 4); got != "▁▁▁▁" {
+		t.Errorf("sparkline(nil) = %q, want flat baseline", got)
+	}
+}
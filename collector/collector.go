@@ -0,0 +1,195 @@
+// Package collector fetches point-in-time snapshots of host network state:
+// connections, listening ports, and interface counters. It is the single
+// implementation shared by the TUI and the Prometheus exporter so the two
+// never drift in how they interpret system state; each still takes its own
+// snapshots on its own schedule.
+package collector
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	psnet "github.com/shirou/gopsutil/v3/net"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// Connection is a single active socket, TCP or UDP, v4 or v6.
+type Connection struct {
+	Proto   string
+	Local   string
+	Remote  string
+	State   string
+	PID     int32
+	Process string
+}
+
+// Port is a de-duplicated listening socket.
+type Port struct {
+	Port    uint32
+	Proto   string
+	Addr    string
+	PID     int32
+	Process string
+}
+
+// Iface is a non-loopback network interface and its cumulative byte counters.
+type Iface struct {
+	Name  string
+	Up    bool
+	Addrs []string
+	RX    uint64
+	TX    uint64
+}
+
+// Connections returns every active socket known to the OS.
+func Connections() []Connection {
+	conns, err := psnet.Connections("all")
+	if err != nil {
+		return nil
+	}
+
+	var result []Connection
+	procCache := make(map[int32]string)
+
+	for _, c := range conns {
+		if c.Status == "" {
+			continue
+		}
+
+		conn := Connection{
+			Proto:  protoString(c.Type, c.Family),
+			Local:  formatAddr(c.Laddr.IP, c.Laddr.Port),
+			Remote: formatAddr(c.Raddr.IP, c.Raddr.Port),
+			State:  c.Status,
+			PID:    c.Pid,
+		}
+
+		if c.Pid > 0 {
+			conn.Process = processName(c.Pid, procCache)
+		}
+
+		result = append(result, conn)
+	}
+
+	return result
+}
+
+// Ports returns every socket currently in LISTEN state, one row per
+// (port, protocol) pair.
+func Ports() []Port {
+	conns, err := psnet.Connections("all")
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var result []Port
+	procCache := make(map[int32]string)
+
+	for _, c := range conns {
+		if c.Status != "LISTEN" {
+			continue
+		}
+
+		key := fmt.Sprintf("%d-%d", c.Laddr.Port, c.Type)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		p := Port{
+			Port:  c.Laddr.Port,
+			Proto: protoString(c.Type, c.Family),
+			Addr:  c.Laddr.IP,
+		}
+
+		if c.Pid > 0 {
+			p.PID = c.Pid
+			p.Process = processName(c.Pid, procCache)
+		}
+
+		result = append(result, p)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Port < result[j].Port
+	})
+
+	return result
+}
+
+// Interfaces returns every non-loopback interface with its address list and
+// cumulative rx/tx byte counters.
+func Interfaces() []Iface {
+	netIfaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+
+	counters, _ := psnet.IOCounters(true)
+	ioMap := make(map[string]psnet.IOCountersStat)
+	for _, c := range counters {
+		ioMap[c.Name] = c
+	}
+
+	var result []Iface
+	for _, ni := range netIfaces {
+		// Skip loopback.
+		if ni.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		ifc := Iface{
+			Name: ni.Name,
+			Up:   ni.Flags&net.FlagUp != 0,
+		}
+
+		if addrs, err := ni.Addrs(); err == nil {
+			for _, a := range addrs {
+				ifc.Addrs = append(ifc.Addrs, a.String())
+			}
+		}
+
+		if io, ok := ioMap[ni.Name]; ok {
+			ifc.RX = io.BytesRecv
+			ifc.TX = io.BytesSent
+		}
+
+		result = append(result, ifc)
+	}
+
+	return result
+}
+
+func processName(pid int32, cache map[int32]string) string {
+	if name, ok := cache[pid]; ok {
+		return name
+	}
+	name := ""
+	if p, err := process.NewProcess(pid); err == nil {
+		if n, err := p.Name(); err == nil {
+			name = n
+		}
+	}
+	cache[pid] = name
+	return name
+}
+
+func protoString(connType, family uint32) string {
+	proto := "tcp"
+	if connType == 2 {
+		proto = "udp"
+	}
+	if family == 10 || family == 23 {
+		proto += "6"
+	}
+	return proto
+}
+
+func formatAddr(ip string, port uint32) string {
+	if ip == "" {
+		ip = "*"
+	}
+	return fmt.Sprintf("%s:%d", ip, port)
+}
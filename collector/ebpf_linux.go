@@ -0,0 +1,188 @@
+//go:build linux && cgo && nettui_ebpf
+
+// This file only builds with -tags nettui_ebpf: it depends on generated
+// bpf2go bindings (netprobeObjects/loadNetprobeObjects) that are not
+// committed, and on vmlinux.h and the libbpf bpf/*.h headers under
+// bpf/headers that aren't vendored either. To build it: vendor those headers, then run
+//
+//	go generate ./collector/... && go build -tags nettui_ebpf ./...
+//
+// Without the tag (the default, including plain `go build ./...` on linux
+// with cgo enabled), ebpf_stub.go is used instead.
+
+package collector
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cilium/ebpf/link"
+	"github.com/cilium/ebpf/ringbuf"
+)
+
+//go:generate go run github.com/cilium/ebpf/cmd/bpf2go -cc clang netprobe bpf/netprobe.c -- -I bpf/headers
+
+// ephemeralWindow bounds how many short-lived flows we remember between
+// Snapshot calls, same rationale as the packet ring buffer in the TUI.
+const ephemeralWindow = 256
+
+// ephemeralTTL is how long a captured flow stays visible to Snapshot
+// callers. It's a few ticks wide so the TUI and the /metrics exporter can
+// both observe it even though they poll independently.
+const ephemeralTTL = 10 * time.Second
+
+// timedConn pairs a captured flow with when poll saw it, so Snapshot can
+// age entries out instead of draining them.
+type timedConn struct {
+	conn Connection
+	seen time.Time
+}
+
+// EBPFSource layers a kprobe/tcp_connect + kretprobe/inet_csk_accept tracer
+// on top of GopsutilSource so Connections also surfaces flows that opened
+// and closed entirely between two poll ticks.
+type EBPFSource struct {
+	objs   netprobeObjects
+	links  []link.Link
+	reader *ringbuf.Reader
+	base   GopsutilSource
+
+	mu     sync.Mutex
+	recent []timedConn
+}
+
+func newEBPFSource() (Source, error) {
+	objs := netprobeObjects{}
+	if err := loadNetprobeObjects(&objs, nil); err != nil {
+		return nil, fmt.Errorf("load ebpf objects: %w", err)
+	}
+
+	connectLink, err := link.Kprobe("tcp_connect", objs.KprobeTcpConnect, nil)
+	if err != nil {
+		objs.Close()
+		return nil, fmt.Errorf("attach kprobe/tcp_connect: %w", err)
+	}
+
+	acceptLink, err := link.Kretprobe("inet_csk_accept", objs.KretprobeInetCskAccept, nil)
+	if err != nil {
+		connectLink.Close()
+		objs.Close()
+		return nil, fmt.Errorf("attach kretprobe/inet_csk_accept: %w", err)
+	}
+
+	reader, err := ringbuf.NewReader(objs.Events)
+	if err != nil {
+		acceptLink.Close()
+		connectLink.Close()
+		objs.Close()
+		return nil, fmt.Errorf("open ringbuf reader: %w", err)
+	}
+
+	src := &EBPFSource{
+		objs:   objs,
+		links:  []link.Link{connectLink, acceptLink},
+		reader: reader,
+	}
+	go src.poll()
+	return src, nil
+}
+
+// poll drains the ring buffer until the reader is closed in Close.
+func (s *EBPFSource) poll() {
+	for {
+		record, err := s.reader.Read()
+		if err != nil {
+			return
+		}
+		conn, ok := decodeNetprobeEvent(record.RawSample)
+		if !ok {
+			continue
+		}
+
+		s.mu.Lock()
+		s.recent = append(s.recent, timedConn{conn: conn, seen: time.Now()})
+		if len(s.recent) > ephemeralWindow {
+			s.recent = s.recent[len(s.recent)-ephemeralWindow:]
+		}
+		s.mu.Unlock()
+	}
+}
+
+// Snapshot reads the live ephemeral window non-destructively: entries age
+// out by timestamp rather than being drained on read, so the TUI tick and
+// the /metrics exporter can both observe the same flows instead of racing
+// to consume them first.
+func (s *EBPFSource) Snapshot() ([]Connection, []Port, []Iface, error) {
+	conns, ports, ifaces, err := s.base.Snapshot()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cutoff := time.Now().Add(-ephemeralTTL)
+	s.mu.Lock()
+	live := s.recent[:0]
+	ephemeral := make([]Connection, 0, len(s.recent))
+	for _, tc := range s.recent {
+		if tc.seen.Before(cutoff) {
+			continue
+		}
+		live = append(live, tc)
+		ephemeral = append(ephemeral, tc.conn)
+	}
+	s.recent = live
+	s.mu.Unlock()
+
+	return append(conns, ephemeral...), ports, ifaces, nil
+}
+
+// Close tears down the ring buffer reader, both probes, and the loaded
+// program/map set. Safe to call once when the TUI exits.
+func (s *EBPFSource) Close() error {
+	s.reader.Close()
+	for _, l := range s.links {
+		l.Close()
+	}
+	return s.objs.Close()
+}
+
+// decodeNetprobeEvent unpacks the fixed-layout netprobe_event struct the C
+// side writes into the ring buffer (see bpf/netprobe.c).
+func decodeNetprobeEvent(raw []byte) (Connection, bool) {
+	const wantLen = 4 + 4 + 2 + 2 + 4 + 1 + 16
+	if len(raw) < wantLen {
+		return Connection{}, false
+	}
+
+	saddr := net.IP(raw[0:4]).String()
+	daddr := net.IP(raw[4:8]).String()
+	sport := binary.LittleEndian.Uint16(raw[8:10])
+	dport := binary.LittleEndian.Uint16(raw[10:12])
+	pid := binary.LittleEndian.Uint32(raw[12:16])
+	direction := raw[16]
+	comm := nullTerminated(raw[17:33])
+
+	conn := Connection{
+		Proto:   "tcp",
+		Local:   fmt.Sprintf("%s:%d", saddr, sport),
+		Remote:  fmt.Sprintf("%s:%d", daddr, dport),
+		State:   "EPHEMERAL",
+		PID:     int32(pid),
+		Process: comm,
+	}
+	if direction == 1 {
+		conn.Local, conn.Remote = conn.Remote, conn.Local
+	}
+	return conn, true
+}
+
+func nullTerminated(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
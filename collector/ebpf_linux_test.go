@@ -0,0 +1,53 @@
+//go:build linux && cgo && nettui_ebpf
+
+package collector
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func encodeNetprobeEvent(saddr, daddr [4]byte, sport, dport uint16, pid uint32, direction byte, comm string) []byte {
+	raw := make([]byte, 4+4+2+2+4+1+16)
+	copy(raw[0:4], saddr[:])
+	copy(raw[4:8], daddr[:])
+	binary.LittleEndian.PutUint16(raw[8:10], sport)
+	binary.LittleEndian.PutUint16(raw[10:12], dport)
+	binary.LittleEndian.PutUint32(raw[12:16], pid)
+	raw[16] = direction
+	copy(raw[17:17+len(comm)], comm)
+	return raw
+}
+
+func TestDecodeNetprobeEvent(t *testing.T) {
+	raw := encodeNetprobeEvent([4]byte{10, 0, 0, 1}, [4]byte{93, 184, 216, 34}, 54321, 443, 4242, 0, "curl")
+
+	conn, ok := decodeNetprobeEvent(raw)
+	if !ok {
+		t.Fatalf("decodeNetprobeEvent() ok = false, want true")
+	}
+	if conn.Local != "10.0.0.1:54321" || conn.Remote != "93.184.216.34:443" {
+		t.Errorf("outbound conn = %+v, want local 10.0.0.1:54321 remote 93.184.216.34:443", conn)
+	}
+	if conn.PID != 4242 || conn.Process != "curl" || conn.State != "EPHEMERAL" {
+		t.Errorf("conn metadata = %+v, want pid 4242 process curl state EPHEMERAL", conn)
+	}
+}
+
+func TestDecodeNetprobeEventInboundSwapsEndpoints(t *testing.T) {
+	raw := encodeNetprobeEvent([4]byte{10, 0, 0, 1}, [4]byte{10, 0, 0, 2}, 22, 51000, 9, 1, "sshd")
+
+	conn, ok := decodeNetprobeEvent(raw)
+	if !ok {
+		t.Fatalf("decodeNetprobeEvent() ok = false, want true")
+	}
+	if conn.Local != "10.0.0.2:51000" || conn.Remote != "10.0.0.1:22" {
+		t.Errorf("inbound conn = %+v, want swapped local/remote", conn)
+	}
+}
+
+func TestDecodeNetprobeEventTooShort(t *testing.T) {
+	if _, ok := decodeNetprobeEvent(make([]byte, 10)); ok {
+		t.Errorf("decodeNetprobeEvent(short buffer) ok = true, want false")
+	}
+}
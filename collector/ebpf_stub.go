@@ -0,0 +1,13 @@
+//go:build !linux || !cgo || !nettui_ebpf
+
+package collector
+
+import "errors"
+
+// newEBPFSource is unavailable unless built on linux with cgo and
+// -tags nettui_ebpf: the real implementation in ebpf_linux.go attaches
+// kernel probes via cilium/ebpf, and its generated bindings are only
+// produced by the go:generate step that tag enables.
+func newEBPFSource() (Source, error) {
+	return nil, errors.New("ebpf source requires building on linux with cgo and -tags nettui_ebpf (see ebpf_linux.go)")
+}
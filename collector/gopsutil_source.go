@@ -0,0 +1,10 @@
+package collector
+
+// GopsutilSource is the default Source, backed by gopsutil's /proc readers.
+// It polls fresh state on every Snapshot call, same as the package-level
+// Connections/Ports/Interfaces functions it wraps.
+type GopsutilSource struct{}
+
+func (GopsutilSource) Snapshot() ([]Connection, []Port, []Iface, error) {
+	return Connections(), Ports(), Interfaces(), nil
+}
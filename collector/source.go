@@ -0,0 +1,25 @@
+package collector
+
+import "fmt"
+
+// Source is anything that can produce a point-in-time snapshot of host
+// network state. GopsutilSource is the default; SSSource and the optional
+// eBPF source are alternative backends selected via --source.
+type Source interface {
+	Snapshot() ([]Connection, []Port, []Iface, error)
+}
+
+// NewSource resolves a --source flag value to a Source implementation.
+// An empty name selects the default gopsutil-based source.
+func NewSource(name string) (Source, error) {
+	switch name {
+	case "", "gopsutil":
+		return GopsutilSource{}, nil
+	case "ss":
+		return SSSource{}, nil
+	case "ebpf":
+		return newEBPFSource()
+	default:
+		return nil, fmt.Errorf("unknown source %q (want gopsutil, ss, or ebpf)", name)
+	}
+}
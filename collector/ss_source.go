@@ -0,0 +1,198 @@
+package collector
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SSSource backs the TUI with the `ss` and `ip` CLI tools instead of
+// gopsutil, for systems where shelling out is preferred (containers
+// without /proc access to other namespaces, minimal images with iproute2
+// but no cgo toolchain, etc).
+type SSSource struct{}
+
+var ssProcRe = regexp.MustCompile(`\(\("([^"]+)",pid=(\d+)`)
+
+func (SSSource) Snapshot() ([]Connection, []Port, []Iface, error) {
+	conns, err := ssConnections()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("ss: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var ports []Port
+	for _, c := range conns {
+		if c.State != "LISTEN" {
+			continue
+		}
+		key := c.Proto + c.Local
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		addr, portNum := splitAddrPort(c.Local)
+		ports = append(ports, Port{
+			Port:    portNum,
+			Proto:   c.Proto,
+			Addr:    addr,
+			PID:     c.PID,
+			Process: c.Process,
+		})
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i].Port < ports[j].Port })
+
+	ifaces, err := ipLinkInterfaces()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("ip: %w", err)
+	}
+
+	return conns, ports, ifaces, nil
+}
+
+// ssConnections runs `ss -tunapH` and parses every socket line into a
+// Connection. The -H flag suppresses the header row.
+func ssConnections() ([]Connection, error) {
+	out, err := exec.Command("ss", "-tunapH").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Connection
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		conn := Connection{
+			Proto:  fields[0],
+			State:  fields[1],
+			Local:  fields[4],
+			Remote: "*:0",
+		}
+		if len(fields) > 5 {
+			conn.Remote = fields[5]
+		}
+		if m := ssProcRe.FindStringSubmatch(scanner.Text()); m != nil {
+			conn.Process = m[1]
+			if pid, err := strconv.ParseInt(m[2], 10, 32); err == nil {
+				conn.PID = int32(pid)
+			}
+		}
+
+		result = append(result, conn)
+	}
+	return result, scanner.Err()
+}
+
+// ipLinkInterfaces runs `ip -s link` for rx/tx byte counters and fills in
+// addresses and the up/down flag from the standard library, since `ip -s
+// link` alone doesn't report assigned addresses.
+func ipLinkInterfaces() ([]Iface, error) {
+	out, err := exec.Command("ip", "-s", "link").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	addrsByName := make(map[string][]string)
+	upByName := make(map[string]bool)
+	if netIfaces, err := net.Interfaces(); err == nil {
+		for _, ni := range netIfaces {
+			upByName[ni.Name] = ni.Flags&net.FlagUp != 0
+			if addrs, err := ni.Addrs(); err == nil {
+				for _, a := range addrs {
+					addrsByName[ni.Name] = append(addrsByName[ni.Name], a.String())
+				}
+			}
+		}
+	}
+
+	var result []Iface
+	lines := strings.Split(string(out), "\n")
+	for i := 0; i < len(lines); i++ {
+		name, ok := parseIPLinkHeader(lines[i])
+		if !ok {
+			continue
+		}
+		if name == "lo" {
+			continue
+		}
+
+		ifc := Iface{Name: name, Up: upByName[name], Addrs: addrsByName[name]}
+
+		// Byte counters sit on the line right after each "RX:"/"TX:" label line.
+		for j := i + 1; j < len(lines) && j < i+8; j++ {
+			fields := strings.Fields(lines[j])
+			if len(fields) == 0 {
+				continue
+			}
+			if j+1 >= len(lines) {
+				continue
+			}
+			switch fields[0] {
+			case "RX:":
+				if rx, ok := firstUint(lines[j+1]); ok {
+					ifc.RX = rx
+				}
+			case "TX:":
+				if tx, ok := firstUint(lines[j+1]); ok {
+					ifc.TX = tx
+				}
+			}
+		}
+
+		result = append(result, ifc)
+	}
+
+	return result, nil
+}
+
+// parseIPLinkHeader extracts the interface name from a line like
+// "2: eth0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 ...".
+func parseIPLinkHeader(line string) (string, bool) {
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return "", false
+	}
+	parts := strings.SplitN(line, ":", 3)
+	if len(parts) < 2 {
+		return "", false
+	}
+	name := strings.TrimSpace(parts[1])
+	if name == "" {
+		return "", false
+	}
+	// Strip any "@peer" suffix vlan/veth interfaces report.
+	name, _, _ = strings.Cut(name, "@")
+	return name, true
+}
+
+func firstUint(line string) (uint64, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+func splitAddrPort(addrPort string) (string, uint32) {
+	idx := strings.LastIndex(addrPort, ":")
+	if idx < 0 {
+		return addrPort, 0
+	}
+	addr := addrPort[:idx]
+	port, _ := strconv.ParseUint(addrPort[idx+1:], 10, 32)
+	return addr, uint32(port)
+}
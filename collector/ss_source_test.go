@@ -0,0 +1,89 @@
+package collector
+
+import "testing"
+
+func TestSplitAddrPort(t *testing.T) {
+	cases := []struct {
+		name     string
+		addrPort string
+		wantAddr string
+		wantPort uint32
+	}{
+		{name: "ipv4", addrPort: "127.0.0.1:8080", wantAddr: "127.0.0.1", wantPort: 8080},
+		{name: "ipv6", addrPort: "[::1]:443", wantAddr: "[::1]", wantPort: 443},
+		{name: "no port", addrPort: "127.0.0.1", wantAddr: "127.0.0.1", wantPort: 0},
+		{name: "non-numeric port ignored", addrPort: "eth0:*", wantAddr: "eth0", wantPort: 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			addr, port := splitAddrPort(c.addrPort)
+			if addr != c.wantAddr || port != c.wantPort {
+				t.Errorf("splitAddrPort(%q) = (%q, %d), want (%q, %d)", c.addrPort, addr, port, c.wantAddr, c.wantPort)
+			}
+		})
+	}
+}
+
+func TestParseIPLinkHeader(t *testing.T) {
+	cases := []struct {
+		name     string
+		line     string
+		wantName string
+		wantOK   bool
+	}{
+		{
+			name:     "physical interface",
+			line:     "2: eth0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc noqueue state UP",
+			wantName: "eth0",
+			wantOK:   true,
+		},
+		{
+			name:     "veth peer suffix stripped",
+			line:     "3: veth1234@if5: <BROADCAST,MULTICAST> mtu 1500",
+			wantName: "veth1234",
+			wantOK:   true,
+		},
+		{
+			name:   "indented byte-counter line is not a header",
+			line:   "    RX: bytes  packets  errors  dropped missed  mcast",
+			wantOK: false,
+		},
+		{
+			name:   "empty line",
+			line:   "",
+			wantOK: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			name, ok := parseIPLinkHeader(c.line)
+			if ok != c.wantOK || (ok && name != c.wantName) {
+				t.Errorf("parseIPLinkHeader(%q) = (%q, %v), want (%q, %v)", c.line, name, ok, c.wantName, c.wantOK)
+			}
+		})
+	}
+}
+
+func TestFirstUint(t *testing.T) {
+	cases := []struct {
+		name string
+		line string
+		want uint64
+		ok   bool
+	}{
+		{name: "counters line", line: "1234567 8901 0 0 0 0", want: 1234567, ok: true},
+		{name: "empty line", line: "", ok: false},
+		{name: "non-numeric", line: "RX:", ok: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, ok := firstUint(c.line)
+			if ok != c.ok || got != c.want {
+				t.Errorf("firstUint(%q) = (%d, %v), want (%d, %v)", c.line, got, ok, c.want, c.ok)
+			}
+		})
+	}
+}
@@ -5,21 +5,39 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"net"
+	"io"
 	"os"
-	"sort"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	psnet "github.com/shirou/gopsutil/v3/net"
-	"github.com/shirou/gopsutil/v3/process"
+
+	"net-tui/collector"
 )
 
 func main() {
-	p := tea.NewProgram(newModel(), tea.WithAltScreen())
+	metricsAddr := flag.String("metrics-addr", "", "if set, serve Prometheus metrics on this address (e.g. :9100) alongside the TUI")
+	sourceName := flag.String("source", "gopsutil", "data source backend: gopsutil, ss, or ebpf")
+	flag.Parse()
+
+	src, err := collector.NewSource(*sourceName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *metricsAddr != "" {
+		if err := startMetricsServer(*metricsAddr, src); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	p := tea.NewProgram(newModel(src), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
@@ -35,9 +53,9 @@ type tickMsg time.Time
 
 // carries refreshed network data
 type dataMsg struct {
-	connections []connection
-	ports       []port
-	interfaces  []iface
+	connections []collector.Connection
+	ports       []collector.Port
+	interfaces  []collector.Iface
 }
 
 // ----------------------------------------------------------------------------
@@ -51,10 +69,13 @@ const (
 	tabConnections tab = iota
 	tabPorts
 	tabInterfaces
+	tabPackets
 )
 
+const tabCount = 4
+
 func (t tab) String() string {
-	return [...]string{"Connections", "Ports", "Interfaces"}[t]
+	return [...]string{"Connections", "Ports", "Interfaces", "Packets"}[t]
 }
 
 // model holds all application state.
@@ -65,18 +86,33 @@ type model struct {
 	width  int
 	height int
 
-	connections []connection
-	ports       []port
-	interfaces  []iface
+	source      collector.Source
+	connections []collector.Connection
+	ports       []collector.Port
+	interfaces  []collector.Iface
+	bandwidth   map[string]*ifaceTracker
+
+	packets packetCapture
+
+	mode        inputMode
+	confirm     *confirmState
+	filterInput textinput.Model
+	filterQuery string
+	actionMsg   string
 }
 
-func newModel() model {
-	return model{tab: tabConnections}
+func newModel(source collector.Source) model {
+	return model{
+		tab:       tabConnections,
+		source:    source,
+		bandwidth: make(map[string]*ifaceTracker),
+		packets:   newPacketCapture(),
+	}
 }
 
 // Init starts the initial data fetch and tick timer.
 func (m model) Init() tea.Cmd {
-	return tea.Batch(fetchData, tick())
+	return tea.Batch(fetchData(m.source), tick())
 }
 
 // Update handles all messages and returns the updated model.
@@ -89,26 +125,48 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 	case tickMsg:
-		return m, tea.Batch(fetchData, tick())
+		return m, tea.Batch(fetchData(m.source), tick())
 	case dataMsg:
 		m.connections = msg.connections
 		m.ports = msg.ports
 		m.interfaces = msg.interfaces
+		m.observeBandwidth(time.Now())
 		m.clampCursor()
 		return m, nil
+	case packetMsg, packetErrMsg, capStartedMsg, packetClosedMsg:
+		cmd := m.packets.handleMsg(msg)
+		return m, cmd
 	}
 	return m, nil
 }
 
 func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.mode != modeNormal {
+		nm, cmd, _ := m.handleActionKey(msg)
+		return nm, cmd
+	}
+
+	if m.tab == tabPackets && m.packets.wantsKey(msg) {
+		cmd := m.packets.handleKey(msg, m.interfaces)
+		return m, cmd
+	}
+
+	if nm, cmd, handled := m.handleActionKey(msg); handled {
+		return nm, cmd
+	}
+
 	switch msg.String() {
 	case "q", "ctrl+c":
+		m.packets.stop()
+		if closer, ok := m.source.(io.Closer); ok {
+			closer.Close()
+		}
 		return m, tea.Quit
 	case "tab", "l", "right":
-		m.tab = (m.tab + 1) % 3
+		m.tab = (m.tab + 1) % tabCount
 		m.cursor, m.offset = 0, 0
 	case "shift+tab", "h", "left":
-		m.tab = (m.tab + 2) % 3
+		m.tab = (m.tab + tabCount - 1) % tabCount
 		m.cursor, m.offset = 0, 0
 	case "j", "down":
 		m.cursor++
@@ -128,6 +186,8 @@ func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.tab, m.cursor, m.offset = tabPorts, 0, 0
 	case "3":
 		m.tab, m.cursor, m.offset = tabInterfaces, 0, 0
+	case "4":
+		m.tab, m.cursor, m.offset = tabPackets, 0, 0
 	}
 	return m, nil
 }
@@ -145,9 +205,9 @@ func (m *model) clampCursor() {
 func (m model) listLen() int {
 	switch m.tab {
 	case tabConnections:
-		return len(m.connections)
+		return len(m.filteredConnections())
 	case tabPorts:
-		return len(m.ports)
+		return len(m.filteredPorts())
 	case tabInterfaces:
 		return len(m.interfaces)
 	}
@@ -178,7 +238,7 @@ func (m model) viewHeader() string {
 
 func (m model) viewTabs() string {
 	var tabs []string
-	for i := range 3 {
+	for i := range tabCount {
 		t := tab(i)
 		style := styles.tabInactive
 		if t == m.tab {
@@ -202,12 +262,28 @@ func (m model) viewContent() string {
 		return m.viewPorts(height)
 	case tabInterfaces:
 		return m.viewInterfaces(height)
+	case tabPackets:
+		return m.packets.view(height, m.interfaces)
 	}
 	return ""
 }
 
 func (m model) viewFooter() string {
-	help := "q quit • tab/1-3 switch • j/k navigate"
+	switch m.mode {
+	case modeConfirm:
+		return "\n" + styles.stateDown.Render(fmt.Sprintf(
+			"send %s to pid %d? (y/n)", m.confirm.label, m.confirm.pid))
+	case modeFilter:
+		return "\n" + styles.header.Render("filter: ") + m.filterInput.View()
+	}
+
+	help := "q quit • tab/1-4 switch • j/k down/up navigate • x/X kill • y copy • / filter"
+	if m.filterQuery != "" {
+		help = fmt.Sprintf("filter %q (enter / with empty value to clear) • ", m.filterQuery) + help
+	}
+	if m.actionMsg != "" {
+		return "\n" + styles.dim.Render(m.actionMsg)
+	}
 	return "\n" + styles.dim.Render(help)
 }
 
@@ -222,17 +298,18 @@ func (m *model) viewConnections(height int) string {
 		"PROTO", "LOCAL", "REMOTE", "STATE", "PROCESS")
 	b.WriteString(styles.header.Render(header) + "\n")
 
+	conns := m.filteredConnections()
 	m.adjustOffset(height - 1)
-	visible := m.visibleRange(len(m.connections), height-1)
+	visible := m.visibleRange(len(conns), height-1)
 
 	for i := visible.start; i < visible.end; i++ {
-		c := m.connections[i]
+		c := conns[i]
 		line := fmt.Sprintf("%-7s %-21s %-21s %-11s %s",
-			c.proto,
-			truncate(c.local, 21),
-			truncate(c.remote, 21),
-			c.state,
-			truncate(c.process, 15),
+			c.Proto,
+			truncate(c.Local, 21),
+			truncate(c.Remote, 21),
+			c.State,
+			truncate(c.Process, 15),
 		)
 		if i == m.cursor {
 			b.WriteString(styles.selected.Render(line))
@@ -242,7 +319,7 @@ func (m *model) viewConnections(height int) string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString(styles.dim.Render(fmt.Sprintf("\n%d connections", len(m.connections))))
+	b.WriteString(styles.dim.Render(fmt.Sprintf("\n%d/%d connections", len(conns), len(m.connections))))
 	return b.String()
 }
 
@@ -257,21 +334,22 @@ func (m *model) viewPorts(height int) string {
 		"PORT", "PROTO", "ADDRESS", "PID", "PROCESS")
 	b.WriteString(styles.header.Render(header) + "\n")
 
+	ports := m.filteredPorts()
 	m.adjustOffset(height - 1)
-	visible := m.visibleRange(len(m.ports), height-1)
+	visible := m.visibleRange(len(ports), height-1)
 
 	for i := visible.start; i < visible.end; i++ {
-		p := m.ports[i]
-		addr := p.addr
+		p := ports[i]
+		addr := p.Addr
 		if addr == "" || addr == "0.0.0.0" || addr == "::" {
 			addr = "*"
 		}
 		line := fmt.Sprintf("%-7d %-7s %-16s %-8d %s",
-			p.port,
-			p.proto,
+			p.Port,
+			p.Proto,
 			addr,
-			p.pid,
-			truncate(p.process, 20),
+			p.PID,
+			truncate(p.Process, 20),
 		)
 		if i == m.cursor {
 			b.WriteString(styles.selected.Render(line))
@@ -281,7 +359,7 @@ func (m *model) viewPorts(height int) string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString(styles.dim.Render(fmt.Sprintf("\n%d listening ports", len(m.ports))))
+	b.WriteString(styles.dim.Render(fmt.Sprintf("\n%d/%d listening ports", len(ports), len(m.ports))))
 	return b.String()
 }
 
@@ -292,29 +370,47 @@ func (m *model) viewPorts(height int) string {
 func (m *model) viewInterfaces(height int) string {
 	var b strings.Builder
 
-	header := fmt.Sprintf("%-12s %-6s %-22s %-12s %s",
-		"NAME", "STATE", "ADDRESS", "RX", "TX")
+	detail := m.selectedIfaceDetail()
+	detailLines := strings.Count(detail, "\n")
+	tableHeight := height - detailLines
+	if tableHeight < 1 {
+		tableHeight = 1
+	}
+
+	header := fmt.Sprintf("%-12s %-6s %-22s %-9s %-9s %-9s %-9s %s",
+		"NAME", "STATE", "ADDRESS", "RX", "TX", "RX/s", "TX/s", "LAST 10")
 	b.WriteString(styles.header.Render(header) + "\n")
 
-	m.adjustOffset(height - 1)
-	visible := m.visibleRange(len(m.interfaces), height-1)
+	m.adjustOffset(tableHeight - 1)
+	visible := m.visibleRange(len(m.interfaces), tableHeight-1)
 
 	for i := visible.start; i < visible.end; i++ {
 		ifc := m.interfaces[i]
 		state := styles.stateDown.Render("down")
-		if ifc.up {
+		if ifc.Up {
 			state = styles.stateUp.Render("up")
 		}
 		addr := "-"
-		if len(ifc.addrs) > 0 {
-			addr = ifc.addrs[0]
+		if len(ifc.Addrs) > 0 {
+			addr = ifc.Addrs[0]
+		}
+
+		rxRate, txRate, spark := "-", "-", strings.Repeat(string(sparkBlocks[0]), 10)
+		if t, ok := m.bandwidth[ifc.Name]; ok && len(t.rates) > 0 {
+			rxRate = formatRate(t.rxRate())
+			txRate = formatRate(t.txRate())
+			spark = sparkline(t.rates, func(s rateSample) float64 { return s.rx + s.tx }, 10)
 		}
-		line := fmt.Sprintf("%-12s %-6s %-22s %-12s %s",
-			ifc.name,
+
+		line := fmt.Sprintf("%-12s %-6s %-22s %-9s %-9s %-9s %-9s %s",
+			ifc.Name,
 			state,
 			truncate(addr, 22),
-			formatBytes(ifc.rx),
-			formatBytes(ifc.tx),
+			formatBytes(ifc.RX),
+			formatBytes(ifc.TX),
+			rxRate,
+			txRate,
+			spark,
 		)
 		if i == m.cursor {
 			b.WriteString(styles.selected.Render(line))
@@ -325,9 +421,24 @@ func (m *model) viewInterfaces(height int) string {
 	}
 
 	b.WriteString(styles.dim.Render(fmt.Sprintf("\n%d interfaces", len(m.interfaces))))
+	b.WriteString(detail)
 	return b.String()
 }
 
+// selectedIfaceDetail renders the expanded bandwidth pane for the currently
+// selected interface, or "" if it has no samples yet.
+func (m *model) selectedIfaceDetail() string {
+	if m.cursor < 0 || m.cursor >= len(m.interfaces) {
+		return ""
+	}
+	ifc := m.interfaces[m.cursor]
+	t, ok := m.bandwidth[ifc.Name]
+	if !ok || len(t.rates) == 0 {
+		return ""
+	}
+	return "\n" + viewIfaceDetail(ifc, t)
+}
+
 // ----------------------------------------------------------------------------
 // Scroll helpers
 // ----------------------------------------------------------------------------
@@ -347,35 +458,6 @@ func (m model) visibleRange(total, pageSize int) visibleRange {
 	return visibleRange{m.offset, end}
 }
 
-// ----------------------------------------------------------------------------
-// Data types
-// ----------------------------------------------------------------------------
-
-type connection struct {
-	proto   string
-	local   string
-	remote  string
-	state   string
-	pid     int32
-	process string
-}
-
-type port struct {
-	port    uint32
-	proto   string
-	addr    string
-	pid     int32
-	process string
-}
-
-type iface struct {
-	name  string
-	up    bool
-	addrs []string
-	rx    uint64
-	tx    uint64
-}
-
 // ----------------------------------------------------------------------------
 // Data fetching
 // ----------------------------------------------------------------------------
@@ -386,165 +468,23 @@ func tick() tea.Cmd {
 	})
 }
 
-func fetchData() tea.Msg {
-	return dataMsg{
-		connections: fetchConnections(),
-		ports:       fetchPorts(),
-		interfaces:  fetchInterfaces(),
-	}
-}
-
-func fetchConnections() []connection {
-	conns, err := psnet.Connections("all")
-	if err != nil {
-		return nil
-	}
-
-	var result []connection
-	procCache := make(map[int32]string)
-
-	for _, c := range conns {
-		if c.Status == "" {
-			continue
-		}
-
-		conn := connection{
-			proto:  protoString(c.Type, c.Family),
-			local:  formatAddr(c.Laddr.IP, c.Laddr.Port),
-			remote: formatAddr(c.Raddr.IP, c.Raddr.Port),
-			state:  c.Status,
-			pid:    c.Pid,
-		}
-
-		if c.Pid > 0 {
-			conn.process = getProcessName(c.Pid, procCache)
-		}
-
-		result = append(result, conn)
-	}
-
-	return result
-}
-
-func fetchPorts() []port {
-	conns, err := psnet.Connections("all")
-	if err != nil {
-		return nil
-	}
-
-	seen := make(map[string]bool)
-	var result []port
-	procCache := make(map[int32]string)
-
-	for _, c := range conns {
-		if c.Status != "LISTEN" {
-			continue
-		}
-
-		key := fmt.Sprintf("%d-%d", c.Laddr.Port, c.Type)
-		if seen[key] {
-			continue
-		}
-		seen[key] = true
-
-		p := port{
-			port:  c.Laddr.Port,
-			proto: protoString(c.Type, c.Family),
-			addr:  c.Laddr.IP,
-			pid:   c.Pid,
-		}
-
-		if c.Pid > 0 {
-			p.process = getProcessName(c.Pid, procCache)
-		}
-
-		result = append(result, p)
-	}
-
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].port < result[j].port
-	})
-
-	return result
-}
-
-func fetchInterfaces() []iface {
-	netIfaces, err := net.Interfaces()
-	if err != nil {
-		return nil
-	}
-
-	counters, _ := psnet.IOCounters(true)
-	ioMap := make(map[string]psnet.IOCountersStat)
-	for _, c := range counters {
-		ioMap[c.Name] = c
-	}
-
-	var result []iface
-	for _, ni := range netIfaces {
-		// Skip loopback.
-		if ni.Flags&net.FlagLoopback != 0 {
-			continue
-		}
-
-		ifc := iface{
-			name: ni.Name,
-			up:   ni.Flags&net.FlagUp != 0,
-		}
-
-		if addrs, err := ni.Addrs(); err == nil {
-			for _, a := range addrs {
-				ifc.addrs = append(ifc.addrs, a.String())
-			}
-		}
-
-		if io, ok := ioMap[ni.Name]; ok {
-			ifc.rx = io.BytesRecv
-			ifc.tx = io.BytesSent
-		}
-
-		result = append(result, ifc)
-	}
-
-	return result
-}
-
-func getProcessName(pid int32, cache map[int32]string) string {
-	if name, ok := cache[pid]; ok {
-		return name
-	}
-	name := ""
-	if p, err := process.NewProcess(pid); err == nil {
-		if n, err := p.Name(); err == nil {
-			name = n
+// fetchData polls the selected backend for a fresh snapshot. Errors are
+// swallowed: a failed snapshot produces no message, so the previous render
+// stays in place until the next tick.
+func fetchData(source collector.Source) tea.Cmd {
+	return func() tea.Msg {
+		conns, ports, ifaces, err := source.Snapshot()
+		if err != nil {
+			return nil
 		}
+		return dataMsg{connections: conns, ports: ports, interfaces: ifaces}
 	}
-	cache[pid] = name
-	return name
 }
 
 // ----------------------------------------------------------------------------
 // Formatting helpers
 // ----------------------------------------------------------------------------
 
-func protoString(connType, family uint32) string {
-	proto := "tcp"
-	if connType == 2 {
-		proto = "udp"
-	}
-	if family == 10 || family == 23 {
-		proto += "6"
-	}
-	return proto
-}
-
-func formatAddr(ip string, port uint32) string {
-	if ip == "" {
-		ip = "*"
-	}
-	return fmt.Sprintf("%s:%d", ip, port)
-}
-
 func formatBytes(b uint64) string {
 	const unit = 1024
 	if b < unit {
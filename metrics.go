@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"net-tui/collector"
+)
+
+var (
+	connectionsDesc = prometheus.NewDesc(
+		"nettui_connections",
+		"Active network connections by protocol, state, and owning process.",
+		[]string{"proto", "state", "process"}, nil,
+	)
+	listeningPortsDesc = prometheus.NewDesc(
+		"nettui_listening_ports",
+		"Listening sockets by protocol and owning process.",
+		[]string{"proto", "process"}, nil,
+	)
+	ifaceRXDesc = prometheus.NewDesc(
+		"nettui_interface_rx_bytes_total",
+		"Cumulative bytes received on an interface.",
+		[]string{"iface"}, nil,
+	)
+	ifaceTXDesc = prometheus.NewDesc(
+		"nettui_interface_tx_bytes_total",
+		"Cumulative bytes sent on an interface.",
+		[]string{"iface"}, nil,
+	)
+)
+
+// nettuiCollector adapts a collector.Source's snapshots to the
+// prometheus.Collector interface, so /metrics reads from the exact same
+// source (gopsutil, ss, or ebpf) the TUI renders, instead of drifting from
+// it by polling its own.
+type nettuiCollector struct {
+	source collector.Source
+}
+
+func (nettuiCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- connectionsDesc
+	ch <- listeningPortsDesc
+	ch <- ifaceRXDesc
+	ch <- ifaceTXDesc
+}
+
+func (c nettuiCollector) Collect(ch chan<- prometheus.Metric) {
+	conns, ports, ifaces, err := c.source.Snapshot()
+	if err != nil {
+		return
+	}
+
+	counts := make(map[[3]string]int)
+	for _, c := range conns {
+		counts[[3]string{c.Proto, c.State, c.Process}]++
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(connectionsDesc, prometheus.GaugeValue, float64(count), key[0], key[1], key[2])
+	}
+
+	portCounts := make(map[[2]string]int)
+	for _, p := range ports {
+		portCounts[[2]string{p.Proto, p.Process}]++
+	}
+	for key, count := range portCounts {
+		ch <- prometheus.MustNewConstMetric(listeningPortsDesc, prometheus.GaugeValue, float64(count), key[0], key[1])
+	}
+
+	for _, ifc := range ifaces {
+		ch <- prometheus.MustNewConstMetric(ifaceRXDesc, prometheus.CounterValue, float64(ifc.RX), ifc.Name)
+		ch <- prometheus.MustNewConstMetric(ifaceTXDesc, prometheus.CounterValue, float64(ifc.TX), ifc.Name)
+	}
+}
+
+// startMetricsServer registers the collector and begins serving /metrics in
+// the background. It returns once the listener is bound, surfacing any bind
+// error synchronously. source is the same collector.Source the TUI is
+// polling; each scrape takes its own snapshot from it.
+func startMetricsServer(addr string, source collector.Source) error {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(nettuiCollector{source: source})
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("metrics listener: %w", err)
+	}
+
+	go http.Serve(ln, mux)
+	return nil
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+
+	"net-tui/collector"
+)
+
+// maxPacketRows bounds the in-memory ring buffer so a busy interface can't
+// grow the capture list without limit.
+const maxPacketRows = 500
+
+// packetStage tracks where the user is in the capture wizard.
+type packetStage int
+
+const (
+	stagePickIface packetStage = iota
+	stagePickFilter
+	stageCapturing
+)
+
+// protocol filters offered in the filter step, matching BPF primitives.
+var packetFilters = []string{"all", "tcp", "udp", "icmp", "arp"}
+
+// packetRow is one captured frame as rendered in the list.
+type packetRow struct {
+	ts      time.Time
+	src     string
+	dst     string
+	proto   string
+	length  int
+	summary string
+}
+
+// packetMsg carries a single captured frame from the capture goroutine.
+type packetMsg packetRow
+
+// packetErrMsg reports a failure opening or reading the capture handle.
+type packetErrMsg struct{ err error }
+
+// capStartedMsg confirms the handle opened and capture is underway.
+type capStartedMsg struct {
+	handle *pcap.Handle
+	ch     chan packetRow
+}
+
+// packetClosedMsg signals the capture channel was closed (handle torn down).
+type packetClosedMsg struct{}
+
+// packetCapture holds all state for the packet-capture tab: the two-step
+// setup wizard (interface, then protocol filter) and the resulting live
+// capture list.
+type packetCapture struct {
+	stage     packetStage
+	ifaceIdx  int
+	filterIdx int
+
+	rows   []packetRow
+	cursor int
+	offset int
+	err    string
+
+	handle *pcap.Handle
+	ch     chan packetRow
+	done   chan struct{}
+}
+
+func newPacketCapture() packetCapture {
+	return packetCapture{}
+}
+
+// wantsKey reports whether the packet wizard should handle this key itself
+// rather than the global connections/ports/interfaces navigation.
+func (p *packetCapture) wantsKey(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "q", "ctrl+c", "tab", "shift+tab", "1", "2", "3", "4":
+		return false
+	}
+	return true
+}
+
+// clampIfaceIdx keeps ifaceIdx in bounds when the interface list changes
+// size out from under the wizard (NIC unplugged, veth torn down, etc.)
+// between two dataMsg refreshes.
+func (p *packetCapture) clampIfaceIdx(ifaces []collector.Iface) {
+	if p.ifaceIdx >= len(ifaces) {
+		p.ifaceIdx = len(ifaces) - 1
+	}
+	if p.ifaceIdx < 0 {
+		p.ifaceIdx = 0
+	}
+}
+
+func (p *packetCapture) handleKey(msg tea.KeyMsg, ifaces []collector.Iface) tea.Cmd {
+	p.clampIfaceIdx(ifaces)
+
+	switch p.stage {
+	case stagePickIface:
+		switch msg.String() {
+		case "j", "down":
+			if p.ifaceIdx < len(ifaces)-1 {
+				p.ifaceIdx++
+			}
+		case "k", "up":
+			if p.ifaceIdx > 0 {
+				p.ifaceIdx--
+			}
+		case "enter":
+			if len(ifaces) > 0 {
+				p.stage = stagePickFilter
+				p.filterIdx = 0
+			}
+		}
+		return nil
+	case stagePickFilter:
+		switch msg.String() {
+		case "j", "down":
+			if p.filterIdx < len(packetFilters)-1 {
+				p.filterIdx++
+			}
+		case "k", "up":
+			if p.filterIdx > 0 {
+				p.filterIdx--
+			}
+		case "esc":
+			p.stage = stagePickIface
+		case "enter":
+			if len(ifaces) == 0 {
+				p.stage = stagePickIface
+				return nil
+			}
+			name := ifaces[p.ifaceIdx].Name
+			filter := packetFilters[p.filterIdx]
+			p.stage = stageCapturing
+			p.rows = nil
+			p.cursor, p.offset = 0, 0
+			p.err = ""
+			p.ch = make(chan packetRow, 64)
+			p.done = make(chan struct{})
+			return startCapture(name, filter, p.ch, p.done)
+		}
+		return nil
+	case stageCapturing:
+		switch msg.String() {
+		case "j", "down":
+			if p.cursor < len(p.rows)-1 {
+				p.cursor++
+			}
+		case "k", "up":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+		case "r", "esc":
+			p.stop()
+			p.stage = stagePickIface
+		}
+		return nil
+	}
+	return nil
+}
+
+// handleMsg routes capture-related tea.Msg values into the capture state.
+func (p *packetCapture) handleMsg(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case capStartedMsg:
+		p.handle = msg.handle
+		return listenPackets(msg.ch)
+	case packetMsg:
+		p.rows = append(p.rows, packetRow(msg))
+		if len(p.rows) > maxPacketRows {
+			p.rows = p.rows[len(p.rows)-maxPacketRows:]
+		}
+		if p.ch != nil {
+			return listenPackets(p.ch)
+		}
+	case packetErrMsg:
+		p.err = msg.err.Error()
+		p.stage = stagePickIface
+	case packetClosedMsg:
+		// capture ended (handle closed elsewhere); nothing more to read.
+	}
+	return nil
+}
+
+// stop closes any in-flight capture handle. Safe to call repeatedly.
+func (p *packetCapture) stop() {
+	if p.handle != nil {
+		p.handle.Close()
+		p.handle = nil
+	}
+	if p.done != nil {
+		close(p.done)
+		p.done = nil
+	}
+	p.ch = nil
+}
+
+// startCapture opens a live handle on iface, applies the BPF filter derived
+// from the chosen protocol, and launches a goroutine that feeds frames into
+// ch until the handle is closed. done is closed by stop() so a goroutine
+// blocked sending into a full ch is released even if nothing is left to
+// read it.
+func startCapture(ifaceName, filter string, ch chan packetRow, done chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		handle, err := pcap.OpenLive(ifaceName, 65535, true, pcap.BlockForever)
+		if err != nil {
+			return packetErrMsg{err}
+		}
+		if bpf := filterToBPF(filter); bpf != "" {
+			if err := handle.SetBPFFilter(bpf); err != nil {
+				handle.Close()
+				return packetErrMsg{err}
+			}
+		}
+
+		go func() {
+			defer close(ch)
+			src := gopacket.NewPacketSource(handle, handle.LinkType())
+			for pkt := range src.Packets() {
+				select {
+				case ch <- toPacketRow(pkt):
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		return capStartedMsg{handle: handle, ch: ch}
+	}
+}
+
+// listenPackets blocks for the next frame on ch, turning channel closure
+// into a packetClosedMsg so Update can stop re-issuing the listen command.
+func listenPackets(ch chan packetRow) tea.Cmd {
+	return func() tea.Msg {
+		row, ok := <-ch
+		if !ok {
+			return packetClosedMsg{}
+		}
+		return packetMsg(row)
+	}
+}
+
+// filterToBPF maps the wizard's protocol choice to a BPF filter expression.
+func filterToBPF(proto string) string {
+	switch proto {
+	case "tcp", "udp", "icmp", "arp":
+		return proto
+	default:
+		return ""
+	}
+}
+
+// toPacketRow extracts the fields net-tui displays from a decoded frame.
+func toPacketRow(pkt gopacket.Packet) packetRow {
+	row := packetRow{
+		ts:     pkt.Metadata().Timestamp,
+		length: pkt.Metadata().Length,
+		src:    "?",
+		dst:    "?",
+		proto:  "other",
+	}
+
+	if net := pkt.NetworkLayer(); net != nil {
+		src, dst := net.NetworkFlow().Endpoints()
+		row.src, row.dst = src.String(), dst.String()
+	} else if link := pkt.LinkLayer(); link != nil {
+		src, dst := link.LinkFlow().Endpoints()
+		row.src, row.dst = src.String(), dst.String()
+	}
+
+	switch {
+	case pkt.Layer(layers.LayerTypeTCP) != nil:
+		row.proto = "tcp"
+		if tcp, ok := pkt.Layer(layers.LayerTypeTCP).(*layers.TCP); ok {
+			row.src = fmt.Sprintf("%s:%d", row.src, tcp.SrcPort)
+			row.dst = fmt.Sprintf("%s:%d", row.dst, tcp.DstPort)
+		}
+	case pkt.Layer(layers.LayerTypeUDP) != nil:
+		row.proto = "udp"
+		if udp, ok := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP); ok {
+			row.src = fmt.Sprintf("%s:%d", row.src, udp.SrcPort)
+			row.dst = fmt.Sprintf("%s:%d", row.dst, udp.DstPort)
+		}
+	case pkt.Layer(layers.LayerTypeICMPv4) != nil:
+		row.proto = "icmp"
+	case pkt.Layer(layers.LayerTypeARP) != nil:
+		row.proto = "arp"
+	}
+
+	row.summary = payloadSummary(pkt)
+	return row
+}
+
+// payloadSummary renders a short, printable preview of the application
+// payload for the packet list (non-printable bytes shown as dots).
+func payloadSummary(pkt gopacket.Packet) string {
+	app := pkt.ApplicationLayer()
+	if app == nil {
+		return ""
+	}
+	payload := app.Payload()
+	if len(payload) > 24 {
+		payload = payload[:24]
+	}
+	var b strings.Builder
+	for _, c := range payload {
+		if c >= 32 && c < 127 {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('.')
+		}
+	}
+	return b.String()
+}
+
+// ----------------------------------------------------------------------------
+// View
+// ----------------------------------------------------------------------------
+
+func (p *packetCapture) view(height int, ifaces []collector.Iface) string {
+	p.clampIfaceIdx(ifaces)
+
+	switch p.stage {
+	case stagePickIface:
+		return p.viewIfacePicker(ifaces)
+	case stagePickFilter:
+		return p.viewFilterPicker(ifaces)
+	default:
+		return p.viewCapture(height)
+	}
+}
+
+func (p *packetCapture) viewIfacePicker(ifaces []collector.Iface) string {
+	var b strings.Builder
+	b.WriteString(styles.header.Render("Select an interface to capture on") + "\n\n")
+
+	if p.err != "" {
+		b.WriteString(styles.stateDown.Render("error: "+p.err) + "\n\n")
+	}
+
+	if len(ifaces) == 0 {
+		b.WriteString(styles.dim.Render("no interfaces available\n"))
+		return b.String()
+	}
+
+	for i, ifc := range ifaces {
+		line := fmt.Sprintf("  %s", ifc.Name)
+		if i == p.ifaceIdx {
+			b.WriteString(styles.selected.Render("> " + ifc.Name))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(styles.dim.Render("\nenter select • j/k move"))
+	return b.String()
+}
+
+func (p *packetCapture) viewFilterPicker(ifaces []collector.Iface) string {
+	if len(ifaces) == 0 {
+		return styles.dim.Render("no interfaces available")
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.header.Render("Protocol filter for "+ifaces[p.ifaceIdx].Name) + "\n\n")
+
+	for i, f := range packetFilters {
+		if i == p.filterIdx {
+			b.WriteString(styles.selected.Render("> " + f))
+		} else {
+			b.WriteString("  " + f)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(styles.dim.Render("\nenter start capture • esc back • j/k move"))
+	return b.String()
+}
+
+func (p *packetCapture) viewCapture(height int) string {
+	var b strings.Builder
+
+	header := fmt.Sprintf("%-13s %-23s %-23s %-6s %-6s %s",
+		"TIME", "SRC", "DST", "PROTO", "LEN", "PAYLOAD")
+	b.WriteString(styles.header.Render(header) + "\n")
+
+	pageSize := height - 1
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+pageSize {
+		p.offset = p.cursor - pageSize + 1
+	}
+	end := min(p.offset+pageSize, len(p.rows))
+
+	for i := p.offset; i < end; i++ {
+		r := p.rows[i]
+		line := fmt.Sprintf("%-13s %-23s %-23s %-6s %-6d %s",
+			r.ts.Format("15:04:05.000"),
+			truncate(r.src, 23),
+			truncate(r.dst, 23),
+			r.proto,
+			r.length,
+			r.summary,
+		)
+		if i == p.cursor {
+			b.WriteString(styles.selected.Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(styles.dim.Render(fmt.Sprintf("\n%d packets • r reset • esc stop", len(p.rows))))
+	return b.String()
+}